@@ -1,9 +1,11 @@
 package horizon
 
 import (
+	"fmt"
+	"math"
 	"net/http"
 	"net/url"
-	"strings"
+	"strconv"
 
 	"github.com/stellar/horizon/actions"
 	"github.com/stellar/horizon/db2"
@@ -28,6 +30,27 @@ type Action struct {
 	App *App
 	Log *log.Entry
 
+	// DefaultTOIDCursor opts an action into synthesizing a descending
+	// cursor at the latest known ledger when the client didn't supply one,
+	// rather than leaving the cursor empty. History list endpoints
+	// (effects, operations, payments, transactions, trades) set this to
+	// avoid a full index scan on `ORDER BY id DESC LIMIT N`.
+	DefaultTOIDCursor bool
+
+	// CursorKind declares how this action's paging cursor should be parsed.
+	// It defaults to db2.CursorInt64; actions that page over int64-pair
+	// cursors (e.g. trades) should set it to db2.CursorInt64Pair.
+	CursorKind db2.CursorKind
+
+	// ResolvedCursor, ResolvedOrder and ResolvedLimit cache the paging
+	// params as of the last call to GetPagingParams, after any
+	// server-side defaulting (e.g. "now" or DefaultTOIDCursor) has been
+	// applied. SelfLink uses these so a client handed a defaulted cursor
+	// keeps pagination stable across requests.
+	ResolvedCursor string
+	ResolvedOrder  string
+	ResolvedLimit  uint64
+
 	hq *history.Q
 	cq *core.Q
 }
@@ -42,7 +65,9 @@ func (action *Action) CoreQ() *core.Q {
 }
 
 // GetPagingParams modifies the base GetPagingParams method to replace
-// cursors that are "now" with the last seen ledger's cursor.
+// cursors that are "now" with the last seen ledger's cursor.  Actions that
+// opt into DefaultTOIDCursor also get this treatment when the client sent a
+// descending request with no cursor at all.
 func (action *Action) GetPagingParams() (cursor string, order string, limit uint64) {
 	if action.Err != nil {
 		return
@@ -50,18 +75,66 @@ func (action *Action) GetPagingParams() (cursor string, order string, limit uint
 
 	cursor, order, limit = action.Base.GetPagingParams()
 
-	if cursor == "now" {
+	if cursor == "now" || (cursor == "" && order == "desc" && action.DefaultTOIDCursor) {
 		tid := toid.ID{
 			LedgerSequence:   ledger.CurrentState().HorizonLatest,
 			TransactionOrder: toid.TransactionMask,
 			OperationOrder:   toid.OperationMask,
 		}
 		cursor = tid.String()
+
+		// actions with a pair-shaped CursorKind (e.g. trades) need a
+		// "<toid>-<tiebreaker>" default, not the bare int64 db2.ParseCursor
+		// would reject for that kind; max out the tiebreaker so the synthesized cursor doesn't
+		// exclude any row tied with the latest TOID.
+		if action.CursorKind == db2.CursorInt64Pair {
+			cursor = fmt.Sprintf("%s-%d", cursor, int64(math.MaxInt64))
+		}
 	}
 
+	action.ResolvedCursor, action.ResolvedOrder, action.ResolvedLimit = cursor, order, limit
+
 	return
 }
 
+// SelfLink returns the "self" pagination link for this request, built from
+// the resolved paging params rather than the raw query string, so that a
+// cursor horizon defaulted on the client's behalf (see GetPagingParams)
+// round-trips back to the client and keeps later requests anchored to the
+// same page.  It calls GetPagingParams itself, so it renders correctly
+// whether or not a paging call has already run for this action.
+func (action *Action) SelfLink() *url.URL {
+	action.GetPagingParams()
+
+	return buildSelfLink(
+		action.BaseURL(),
+		action.Request.URL.Path,
+		action.Request.URL.RawQuery,
+		action.ResolvedCursor,
+		action.ResolvedOrder,
+		action.ResolvedLimit,
+	)
+}
+
+// buildSelfLink mirrors SelfLink's query rewriting but takes its inputs as
+// plain values, so the rewriting itself can be tested without a live
+// Action/App/request.
+func buildSelfLink(base *url.URL, path string, rawQuery string, cursor string, order string, limit uint64) *url.URL {
+	q, _ := url.ParseQuery(rawQuery)
+	if q == nil {
+		q = url.Values{}
+	}
+
+	q.Set(actions.ParamCursor, cursor)
+	q.Set("order", order)
+	q.Set("limit", strconv.FormatUint(limit, 10))
+
+	self := *base
+	self.Path = path
+	self.RawQuery = q.Encode()
+	return &self
+}
+
 // GetPageQuery is a helper that returns a new db.PageQuery struct initialized
 // using the results from a call to GetPagingParams()
 func (action *Action) GetPageQuery() db2.PageQuery {
@@ -69,15 +142,82 @@ func (action *Action) GetPageQuery() db2.PageQuery {
 		return db2.PageQuery{}
 	}
 
-	r, err := db2.NewPageQuery(action.GetPagingParams())
+	cursor, order, limit := action.GetPagingParams()
+	r, err := db2.NewPageQuery(cursor, order, limit, action.CursorKind)
 
 	if err != nil {
 		action.Err = err
+		return r
 	}
 
+	action.clampCursorToRetentionWindow(&r)
+
 	return r
 }
 
+// clampCursorToRetentionWindow sets pq.RetentionBoundary so that a
+// descending query the client issued without its own cursor can't scan
+// behind App.HistoryRetentionCount, without disturbing pq.Cursor itself
+// (which may already have been defaulted to the latest ledger by
+// GetPagingParams, e.g. via DefaultTOIDCursor). This gives the queries
+// built from pq in history.Q a second, lower bound on the TOID column in
+// addition to their existing cursor predicate, letting Postgres use the
+// primary key index instead of a full scan.
+//
+// The decision is based on whether the client supplied a cursor at all,
+// not on whether pq.Cursor happens to be empty by the time this runs --
+// otherwise the clamp would never fire for the very endpoints
+// DefaultTOIDCursor targets, since those always end up with a non-empty
+// pq.Cursor before clampCursorToRetentionWindow is called.
+func (action *Action) clampCursorToRetentionWindow(pq *db2.PageQuery) {
+	if action.Err != nil {
+		return
+	}
+
+	clientSuppliedCursor := action.GetString(actions.ParamCursor) != ""
+	if pq.Order != "desc" || clientSuppliedCursor {
+		return
+	}
+
+	boundary := retentionBoundary(action.App)
+	if boundary == nil {
+		return
+	}
+
+	pq.RetentionBoundary = boundary.ToInt64()
+}
+
+// retentionBoundary returns the oldest TOID still within app's configured
+// retention window, or nil if no window is configured. It is the start of
+// the oldest retained ledger -- not its end -- so that every row in that
+// ledger is still >= the boundary; both clampCursorToRetentionWindow's
+// query-level lower bound and ValidateCursorWithinHistory's 410 check must
+// agree on this same value, or a client-supplied cursor landing in that
+// ledger would pass validation while the no-cursor path silently excluded
+// the ledger's rows.
+func retentionBoundary(app *App) *toid.ID {
+	retention := app.HistoryRetentionCount
+	if retention == 0 {
+		return nil
+	}
+
+	state := ledger.CurrentState()
+	sequence := state.HorizonLatest - int32(retention)
+	if sequence < state.HorizonElder {
+		sequence = state.HorizonElder
+	}
+
+	return toid.New(sequence, 0, 0)
+}
+
+// EmptyLedgerDataAllowed returns true when resource adapters should emit
+// ledger-derived fields (e.g. `last_modified_time`, `closed_at`) as JSON null
+// rather than failing the request when the corresponding history_ledgers row
+// hasn't been ingested yet.
+func (action *Action) EmptyLedgerDataAllowed() bool {
+	return action.App.AllowEmptyLedgerDataResponses
+}
+
 // HistoryQ provides access to queries that access the history portion of
 // horizon's database.
 func (action *Action) HistoryQ() *history.Q {
@@ -101,15 +241,24 @@ func (action *Action) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ValidateCursorAsDefault ensures that the cursor parameter is valid in the way
-// it is normally used, i.e. it is either the string "now" or a string of
-// numerals that can be parsed as an int64.
+// ValidateCursorAsDefault ensures that the cursor parameter is valid in the
+// way it is normally used, i.e. it is either the string "now", empty, or a
+// cursor of the shape action.CursorKind declares (a bare int64 by default,
+// or an "<int64>-<int64>" pair for actions like trades).
 func (action *Action) ValidateCursorAsDefault() {
 	if action.Err != nil {
 		return
 	}
 
-	if action.GetString(actions.ParamCursor) == "now" {
+	cursor := action.GetString(actions.ParamCursor)
+	if cursor == "now" || cursor == "" {
+		return
+	}
+
+	if action.CursorKind == db2.CursorInt64Pair {
+		if _, err := db2.ParseCursor(cursor, action.CursorKind); err != nil {
+			action.Err = err
+		}
 		return
 	}
 
@@ -138,29 +287,41 @@ func (action *Action) ValidateCursorWithinHistory() {
 		return
 	}
 
-	var cursor int64
-	var err error
-
-	// HACK: checking for the presence of "-" to see whether we should use
-	// CursorInt64 or CursorInt64Pair is gross.
-	if strings.Contains(pq.Cursor, "-") {
-		cursor, _, err = pq.CursorInt64Pair("-")
-	} else {
-		cursor, err = pq.CursorInt64()
-	}
-
-	if err != nil {
-		action.Err = err
+	// a descending query with no cursor at all is just "give me the latest
+	// page" -- it's bounded by RetentionBoundary at the query level, not by
+	// a 410 here, since there's no client-supplied position to judge.
+	if pq.ParsedCursor == nil {
 		return
 	}
 
-	elder := toid.New(ledger.CurrentState().HorizonElder, 0, 0)
+	chainElder := toid.New(ledger.CurrentState().HorizonElder, 0, 0).ToInt64()
 
-	if cursor <= elder.ToInt64() {
+	var retention *int64
+	if boundary := retentionBoundary(action.App); boundary != nil {
+		v := boundary.ToInt64()
+		retention = &v
+	}
+
+	if cursorBeforeHistory(pq.ParsedCursor, chainElder, retention) {
 		action.Err = &problem.BeforeHistory
 	}
 }
 
+// cursorBeforeHistory reports whether cursor falls at or before the oldest
+// TOID a descending query may return: chainElder, the chain's actual oldest
+// ingested ledger, tightened further by retention when the operator has
+// configured a narrower HistoryRetentionCount window. Split out from
+// ValidateCursorWithinHistory so this comparison -- the core of the 410
+// decision -- can be tested without a live App or ledger.CurrentState().
+func cursorBeforeHistory(cursor db2.Cursor, chainElder int64, retention *int64) bool {
+	elder := chainElder
+	if retention != nil && *retention > elder {
+		elder = *retention
+	}
+
+	return db2.IsBeforeBoundary(cursor, elder)
+}
+
 // BaseURL returns the base url for this requestion, defined as a url containing
 // the Host and Scheme portions of the request uri.
 func (action *Action) BaseURL() *url.URL {
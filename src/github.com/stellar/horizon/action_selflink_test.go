@@ -0,0 +1,43 @@
+package horizon
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestBuildSelfLink_UsesResolvedParamsNotRawQuery(t *testing.T) {
+	base := &url.URL{Scheme: "https", Host: "horizon.example.com"}
+
+	self := buildSelfLink(base, "/effects", "cursor=now&order=desc&limit=10", "12884901890", "desc", 10)
+
+	q := self.Query()
+	if got := q.Get("cursor"); got != "12884901890" {
+		t.Fatalf("expected resolved cursor %q in Self link, got %q", "12884901890", got)
+	}
+
+	if got := q.Get("order"); got != "desc" {
+		t.Fatalf("expected order %q, got %q", "desc", got)
+	}
+
+	if got := q.Get("limit"); got != "10" {
+		t.Fatalf("expected limit %q, got %q", "10", got)
+	}
+
+	if self.Path != "/effects" {
+		t.Fatalf("expected path %q, got %q", "/effects", self.Path)
+	}
+}
+
+func TestBuildSelfLink_PreservesOtherQueryParams(t *testing.T) {
+	base := &url.URL{Scheme: "https", Host: "horizon.example.com"}
+
+	self := buildSelfLink(base, "/trades", "base_asset_type=native", "123-4", "desc", 25)
+
+	if got := self.Query().Get("base_asset_type"); got != "native" {
+		t.Fatalf("expected unrelated query params to survive, got %q", got)
+	}
+
+	if got := self.Query().Get("cursor"); got != "123-4" {
+		t.Fatalf("expected resolved pair cursor %q, got %q", "123-4", got)
+	}
+}
@@ -0,0 +1,42 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/horizon/db2"
+)
+
+func TestCursorBeforeHistory_PastChainElder(t *testing.T) {
+	cursor, err := db2.ParseCursor("5", db2.CursorInt64)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !cursorBeforeHistory(cursor, 20, nil) {
+		t.Fatalf("expected a cursor before the chain's elder ledger to be BeforeHistory")
+	}
+}
+
+func TestCursorBeforeHistory_PastRetentionBoundary(t *testing.T) {
+	cursor, err := db2.ParseCursor("10", db2.CursorInt64)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	retention := int64(20)
+	if !cursorBeforeHistory(cursor, 5, &retention) {
+		t.Fatalf("expected a cursor before the retention boundary to be BeforeHistory even though it's after the chain elder")
+	}
+}
+
+func TestCursorBeforeHistory_WithinHistory(t *testing.T) {
+	cursor, err := db2.ParseCursor("30", db2.CursorInt64)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	retention := int64(20)
+	if cursorBeforeHistory(cursor, 5, &retention) {
+		t.Fatalf("expected a cursor after both the chain elder and the retention boundary to be within history")
+	}
+}
@@ -0,0 +1,40 @@
+package horizon
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/stellar/horizon/protocol"
+	"github.com/stellar/horizon/resourceadapter"
+	"github.com/zenazn/goji/web"
+)
+
+// EffectIndexAction renders a page of effect resources, optionally scoped to
+// a single account, ledger, operation or transaction.
+type EffectIndexAction struct {
+	Action
+}
+
+// Prepare sets up the action the same way Action.Prepare does, additionally
+// declaring this endpoint's cursor shape -- effects page on a
+// "<toid>-<order>" pair rather than a bare int64, see protocol.Effect.ID --
+// and opting it into Action.DefaultTOIDCursor.
+func (action *EffectIndexAction) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
+	action.Action.Prepare(c, w, r)
+	action.CursorKind = db2.CursorInt64Pair
+	action.DefaultTOIDCursor = true
+}
+
+// populateEffectLedgerData resolves dest.LedgerCloseTime from the ledger
+// the effect resulted from, honoring EmptyLedgerDataAllowed() when that
+// ledger hasn't been ingested into history yet.
+func (action *Action) populateEffectLedgerData(dest *protocol.Effect, closedAt time.Time, ledgerFound bool) {
+	if action.Err != nil {
+		return
+	}
+
+	if err := resourceadapter.PopulateEffectLedgerData(action, dest, closedAt, ledgerFound); err != nil {
+		action.Err = err
+	}
+}
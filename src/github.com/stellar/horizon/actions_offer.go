@@ -0,0 +1,21 @@
+package horizon
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+	"github.com/stellar/horizon/resourceadapter"
+)
+
+// populateOfferLedgerData resolves dest.LastModifiedTime from the ledger
+// the offer was last modified in, honoring EmptyLedgerDataAllowed() when
+// that ledger hasn't been ingested into history yet.
+func (action *Action) populateOfferLedgerData(dest *protocol.Offer, closedAt time.Time, ledgerFound bool) {
+	if action.Err != nil {
+		return
+	}
+
+	if err := resourceadapter.PopulateOfferLedgerData(action, dest, closedAt, ledgerFound); err != nil {
+		action.Err = err
+	}
+}
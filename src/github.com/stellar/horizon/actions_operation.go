@@ -0,0 +1,36 @@
+package horizon
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+	"github.com/stellar/horizon/resourceadapter"
+	"github.com/zenazn/goji/web"
+)
+
+// OperationIndexAction renders a page of operation resources, optionally
+// scoped to a single account, ledger or transaction.
+type OperationIndexAction struct {
+	Action
+}
+
+// Prepare sets up the action the same way Action.Prepare does, additionally
+// opting this endpoint into Action.DefaultTOIDCursor.
+func (action *OperationIndexAction) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
+	action.Action.Prepare(c, w, r)
+	action.DefaultTOIDCursor = true
+}
+
+// populateOperationLedgerData resolves dest.LedgerCloseTime from the ledger
+// the operation was applied in, honoring EmptyLedgerDataAllowed() when that
+// ledger hasn't been ingested into history yet.
+func (action *Action) populateOperationLedgerData(dest *protocol.Operation, closedAt time.Time, ledgerFound bool) {
+	if action.Err != nil {
+		return
+	}
+
+	if err := resourceadapter.PopulateOperationLedgerData(action, dest, closedAt, ledgerFound); err != nil {
+		action.Err = err
+	}
+}
@@ -0,0 +1,23 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/stellar/horizon/db2"
+	"github.com/zenazn/goji/web"
+)
+
+// TradeIndexAction renders a page of trade resources, optionally filtered to
+// a single asset pair.
+type TradeIndexAction struct {
+	Action
+}
+
+// Prepare sets up the action the same way Action.Prepare does, additionally
+// declaring this endpoint's cursor shape: trades page on a "<toid>-<order>"
+// pair rather than a bare int64, so the base cursor kind doesn't apply.
+func (action *TradeIndexAction) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
+	action.Action.Prepare(c, w, r)
+	action.CursorKind = db2.CursorInt64Pair
+	action.DefaultTOIDCursor = true
+}
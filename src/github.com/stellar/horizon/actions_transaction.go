@@ -0,0 +1,20 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/zenazn/goji/web"
+)
+
+// TransactionIndexAction renders a page of transaction resources, optionally
+// scoped to a single account or ledger.
+type TransactionIndexAction struct {
+	Action
+}
+
+// Prepare sets up the action the same way Action.Prepare does, additionally
+// opting this endpoint into Action.DefaultTOIDCursor.
+func (action *TransactionIndexAction) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
+	action.Action.Prepare(c, w, r)
+	action.DefaultTOIDCursor = true
+}
@@ -0,0 +1,34 @@
+package horizon
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/stellar/horizon/db2"
+)
+
+// App is the root of horizon's state: it holds the resolved Config plus the
+// database handles that actions reach through to via CoreRepo/HorizonRepo.
+type App struct {
+	Config
+
+	coreRepo    *db2.Repo
+	historyRepo *db2.Repo
+}
+
+// NewApp constructs a new App from cfg, ready to have its database handles
+// attached before serving traffic.
+func NewApp(cfg Config) *App {
+	return &App{Config: cfg}
+}
+
+// CoreRepo returns the repo used to query the connected stellar-core
+// database.
+func (a *App) CoreRepo(ctx context.Context) *db2.Repo {
+	return a.coreRepo
+}
+
+// HorizonRepo returns the repo used to query horizon's own history
+// database.
+func (a *App) HorizonRepo(ctx context.Context) *db2.Repo {
+	return a.historyRepo
+}
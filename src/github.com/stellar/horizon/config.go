@@ -0,0 +1,19 @@
+package horizon
+
+// Config is the configuration for horizon. It gets populated by command
+// line flags and, in turn, drives the behavior of the App built from it.
+type Config struct {
+	// HistoryRetentionCount is the number of ledgers of history horizon
+	// guarantees are queryable behind the latest ledger. Descending history
+	// queries with a cursor older than HorizonLatest - HistoryRetentionCount
+	// return problem.BeforeHistory; ascending queries are not checked against
+	// the window. A value of 0 disables the window
+	// (unlimited history, the default).
+	HistoryRetentionCount uint32
+
+	// AllowEmptyLedgerDataResponses, when true, permits resource adapters to
+	// emit ledger-derived fields (e.g. last_modified_time, closed_at) as
+	// JSON null instead of failing the whole request when the resource's
+	// history_ledgers row hasn't been ingested yet.
+	AllowEmptyLedgerDataResponses bool
+}
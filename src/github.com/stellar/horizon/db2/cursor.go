@@ -0,0 +1,101 @@
+package db2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CursorKind identifies the on-the-wire shape of an endpoint's paging
+// cursor, so ParseCursor doesn't have to sniff the cursor string itself to
+// decide how to parse it.
+type CursorKind int
+
+const (
+	// CursorInt64 is a single int64 TOID, the cursor shape used by most
+	// ledger-ordered resources.
+	CursorInt64 CursorKind = iota
+
+	// CursorInt64Pair is two dash-separated int64s (e.g. "123-4"), used by
+	// resources such as trades that page across two distinct orderings.
+	CursorInt64Pair
+)
+
+// Cursor is a parsed paging cursor, usable both to validate a request
+// against the known ledger range and to bound the queries built from it.
+type Cursor interface {
+	// LedgerSequence returns the ledger sequence encoded in the cursor.
+	LedgerSequence() int32
+
+	// ToInt64 returns the TOID value used to compare against the history
+	// database's elder and retention boundaries.
+	ToInt64() int64
+}
+
+// ParseCursor parses cur according to kind, dispatching to the
+// CursorInt64 or CursorInt64Pair representation as declared by the calling
+// action rather than guessing from the string's shape.
+func ParseCursor(cur string, kind CursorKind) (Cursor, error) {
+	switch kind {
+	case CursorInt64Pair:
+		return parseCursorInt64Pair(cur)
+	default:
+		i, err := strconv.ParseInt(cur, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %s", cur, err)
+		}
+		return cursorInt64(i), nil
+	}
+}
+
+// IsBeforeBoundary reports whether cursor's TOID value falls at or before
+// boundary. Both CursorInt64 and CursorInt64Pair order on their ToInt64()
+// value, so a single comparison here covers the retention/elder check
+// uniformly for every registered CursorKind.
+func IsBeforeBoundary(cursor Cursor, boundary int64) bool {
+	return cursor.ToInt64() <= boundary
+}
+
+type cursorInt64 int64
+
+func (c cursorInt64) LedgerSequence() int32 {
+	return int32(int64(c) >> 32)
+}
+
+func (c cursorInt64) ToInt64() int64 {
+	return int64(c)
+}
+
+// cursorInt64Pair is the parsed form of a "<upper>-<lower>" cursor such as
+// trades use, where upper orders the page and lower breaks ties within it.
+type cursorInt64Pair struct {
+	upper int64
+	lower int64
+}
+
+func (c cursorInt64Pair) LedgerSequence() int32 {
+	return int32(c.upper >> 32)
+}
+
+func (c cursorInt64Pair) ToInt64() int64 {
+	return c.upper
+}
+
+func parseCursorInt64Pair(cur string) (Cursor, error) {
+	parts := strings.SplitN(cur, "-", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor %q: expected \"<int64>-<int64>\"", cur)
+	}
+
+	upper, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %s", cur, err)
+	}
+
+	lower, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %s", cur, err)
+	}
+
+	return cursorInt64Pair{upper: upper, lower: lower}, nil
+}
@@ -0,0 +1,79 @@
+package db2
+
+import "testing"
+
+func TestParseCursor_Int64(t *testing.T) {
+	cursor, err := ParseCursor("12884901890", CursorInt64)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cursor.ToInt64() != 12884901890 {
+		t.Fatalf("got %d, want %d", cursor.ToInt64(), 12884901890)
+	}
+}
+
+func TestParseCursor_Int64Pair(t *testing.T) {
+	cursor, err := ParseCursor("123-4", CursorInt64Pair)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cursor.ToInt64() != 123 {
+		t.Fatalf("got %d, want %d", cursor.ToInt64(), 123)
+	}
+}
+
+func TestParseCursor_Int64PairRoundTrips(t *testing.T) {
+	cases := []string{"123-4", "0-0", "9223372036854775807-1"}
+
+	for _, cur := range cases {
+		if _, err := ParseCursor(cur, CursorInt64Pair); err != nil {
+			t.Errorf("ParseCursor(%q, CursorInt64Pair) returned error: %s", cur, err)
+		}
+	}
+}
+
+func TestParseCursor_Int64PairRejectsMalformed(t *testing.T) {
+	cases := []string{"", "123", "123-", "-4", "abc-4"}
+
+	for _, cur := range cases {
+		if _, err := ParseCursor(cur, CursorInt64Pair); err == nil {
+			t.Errorf("ParseCursor(%q, CursorInt64Pair) expected an error, got none", cur)
+		}
+	}
+}
+
+func TestParseCursor_Int64RejectsPairShapedInput(t *testing.T) {
+	if _, err := ParseCursor("123-4", CursorInt64); err == nil {
+		t.Errorf("ParseCursor(%q, CursorInt64) expected an error, got none", "123-4")
+	}
+}
+
+func TestIsBeforeBoundary(t *testing.T) {
+	cases := []struct {
+		name     string
+		cur      string
+		kind     CursorKind
+		boundary int64
+		expected bool
+	}{
+		{"int64 cursor older than boundary", "10", CursorInt64, 20, true},
+		{"int64 cursor equal to boundary", "20", CursorInt64, 20, true},
+		{"int64 cursor newer than boundary", "30", CursorInt64, 20, false},
+		{"pair cursor older than boundary", "10-4", CursorInt64Pair, 20, true},
+		{"pair cursor equal to boundary", "20-4", CursorInt64Pair, 20, true},
+		{"pair cursor newer than boundary", "30-4", CursorInt64Pair, 20, false},
+	}
+
+	for _, kase := range cases {
+		cursor, err := ParseCursor(kase.cur, kase.kind)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", kase.name, err)
+		}
+
+		if got := IsBeforeBoundary(cursor, kase.boundary); got != kase.expected {
+			t.Errorf("%s: IsBeforeBoundary(%q, %d) = %v, want %v", kase.name, kase.cur, kase.boundary, got, kase.expected)
+		}
+	}
+}
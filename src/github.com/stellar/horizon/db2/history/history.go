@@ -0,0 +1,71 @@
+package history
+
+import (
+	"fmt"
+
+	sq "github.com/lann/squirrel"
+
+	"github.com/stellar/horizon/db2"
+)
+
+// Q is the root query object for the history portion of horizon's
+// database.  Each exported method builds a query for one resource's list
+// endpoint, bounded by the cursor (and, for descending queries, the
+// retention window) carried on the supplied db2.PageQuery.
+type Q struct {
+	Repo *db2.Repo
+}
+
+// Accounts returns a query for a page of account resources. history_accounts
+// rows are keyed on a plain bigserial id, not a ledger TOID, so the
+// retention boundary (a TOID value) doesn't apply here -- see applyCursor.
+func (q *Q) Accounts(pq db2.PageQuery) sq.SelectBuilder {
+	return applyCursor(sq.Select("ha.*").From("history_accounts ha"), pq, "ha.id", false)
+}
+
+// Effects returns a query for a page of effect resources.
+func (q *Q) Effects(pq db2.PageQuery) sq.SelectBuilder {
+	return applyCursor(sq.Select("he.*").From("history_effects he"), pq, "he.history_operation_id", true)
+}
+
+// Operations returns a query for a page of operation resources.
+func (q *Q) Operations(pq db2.PageQuery) sq.SelectBuilder {
+	return applyCursor(sq.Select("hop.*").From("history_operations hop"), pq, "hop.id", true)
+}
+
+// Payments returns a query for a page of payment-shaped operation
+// resources.
+func (q *Q) Payments(pq db2.PageQuery) sq.SelectBuilder {
+	return applyCursor(sq.Select("hop.*").From("history_operations hop").Where("hop.type in (?,?,?,?,?)", 0, 1, 2, 8, 13), pq, "hop.id", true)
+}
+
+// Transactions returns a query for a page of transaction resources.
+func (q *Q) Transactions(pq db2.PageQuery) sq.SelectBuilder {
+	return applyCursor(sq.Select("htx.*").From("history_transactions htx"), pq, "htx.id", true)
+}
+
+// applyCursor bounds sb by pq's cursor on column, ordering and limiting the
+// result the same way. When column is TOID-keyed, isTOIDKeyed additionally
+// ANDs in pq.RetentionBoundary as a lower bound when one is set, so a
+// descending "give me the latest page" query (no client cursor) stays
+// anchored at the latest ledger while still never scanning behind the
+// configured retention window. isTOIDKeyed must be false for columns (like
+// history_accounts.id) that aren't TOID-valued -- RetentionBoundary is a
+// TOID, and comparing it against an unrelated id column would wrongly
+// exclude rows rather than leave them unbounded.
+func applyCursor(sb sq.SelectBuilder, pq db2.PageQuery, column string, isTOIDKeyed bool) sq.SelectBuilder {
+	op := "<"
+	if pq.Order == "asc" {
+		op = ">"
+	}
+
+	if pq.Cursor != "" {
+		sb = sb.Where(fmt.Sprintf("%s %s ?", column, op), pq.Cursor)
+	}
+
+	if isTOIDKeyed && pq.RetentionBoundary > 0 {
+		sb = sb.Where(fmt.Sprintf("%s > ?", column), pq.RetentionBoundary)
+	}
+
+	return sb.OrderBy(fmt.Sprintf("%s %s", column, pq.Order)).Limit(pq.Limit)
+}
@@ -0,0 +1,84 @@
+package history
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stellar/horizon/db2"
+)
+
+func TestEffects_EmptyCursorDescendingContainsRetentionLowerBound(t *testing.T) {
+	pq := db2.PageQuery{Order: "desc", Limit: 10, RetentionBoundary: 42}
+
+	q := &Q{}
+	sql, _, err := q.Effects(pq).ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(sql, "he.history_operation_id > ?") {
+		t.Fatalf("expected query to contain a lower bound predicate, got: %s", sql)
+	}
+}
+
+func TestEffects_CursorAndRetentionBoundaryAreBothApplied(t *testing.T) {
+	pq := db2.PageQuery{Cursor: "100", Order: "desc", Limit: 10, RetentionBoundary: 42}
+
+	q := &Q{}
+	sql, args, err := q.Effects(pq).ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(sql, "he.history_operation_id < ?") {
+		t.Fatalf("expected an upper bound from Cursor, got: %s", sql)
+	}
+
+	if !strings.Contains(sql, "he.history_operation_id > ?") {
+		t.Fatalf("expected a lower bound from RetentionBoundary, got: %s", sql)
+	}
+
+	if len(args) != 2 {
+		t.Fatalf("expected 2 bind args (cursor, retention boundary), got %d: %v", len(args), args)
+	}
+
+	if args[0] != pq.Cursor {
+		t.Fatalf("expected the first bind arg to be the cursor %q, got %v", pq.Cursor, args[0])
+	}
+
+	if args[1] != pq.RetentionBoundary {
+		t.Fatalf("expected the second bind arg to be the retention boundary %d, got %v", pq.RetentionBoundary, args[1])
+	}
+}
+
+func TestEffects_NoRetentionBoundaryOmitsLowerBound(t *testing.T) {
+	pq := db2.PageQuery{Order: "desc", Limit: 10}
+
+	q := &Q{}
+	sql, _, err := q.Effects(pq).ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(sql, "he.history_operation_id > ?") {
+		t.Fatalf("did not expect a lower bound when RetentionBoundary is unset, got: %s", sql)
+	}
+}
+
+func TestAccounts_RetentionBoundaryIsNotApplied(t *testing.T) {
+	pq := db2.PageQuery{Order: "desc", Limit: 10, RetentionBoundary: 42}
+
+	q := &Q{}
+	sql, args, err := q.Accounts(pq).ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(sql, "ha.id > ?") {
+		t.Fatalf("history_accounts.id is not TOID-valued; did not expect a RetentionBoundary lower bound, got: %s", sql)
+	}
+
+	if len(args) != 0 {
+		t.Fatalf("expected no bind args, got %d: %v", len(args), args)
+	}
+}
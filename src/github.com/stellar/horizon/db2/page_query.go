@@ -0,0 +1,67 @@
+package db2
+
+import "fmt"
+
+const (
+	// DefaultPageSize is the default page size used when a request does not
+	// specify a limit.
+	DefaultPageSize = 10
+
+	// MaxPageSize is the largest page size allowed to be requested.
+	MaxPageSize = 200
+)
+
+// PageQuery represents the common paging parameters used by the history
+// endpoints: a cursor to page from, a sort order, and a limit on the number
+// of records returned.
+type PageQuery struct {
+	Cursor string
+	Order  string
+	Limit  uint64
+
+	// RetentionBoundary, when non-zero, is a TOID lower bound that history
+	// queries AND into their predicate in addition to Cursor. It lets a
+	// descending query with no client-supplied cursor still be clamped to
+	// the configured retention window without losing its "start at the
+	// latest ledger" anchor.
+	RetentionBoundary int64
+
+	// ParsedCursor is Cursor parsed according to the CursorKind passed to
+	// NewPageQuery, so callers that need the parsed form (e.g. to compare
+	// against a retention boundary) don't each have to call ParseCursor
+	// again. It is nil when Cursor is empty.
+	ParsedCursor Cursor
+}
+
+// NewPageQuery builds a new PageQuery struct, validating the provided order
+// and limit and parsing cursor according to kind.
+func NewPageQuery(cursor string, order string, limit uint64, kind CursorKind) (PageQuery, error) {
+	if order == "" {
+		order = "asc"
+	}
+
+	if order != "asc" && order != "desc" {
+		return PageQuery{}, fmt.Errorf("invalid order: %q", order)
+	}
+
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+
+	if limit > MaxPageSize {
+		return PageQuery{}, fmt.Errorf("invalid limit: %d is larger than the max of %d", limit, MaxPageSize)
+	}
+
+	pq := PageQuery{Cursor: cursor, Order: order, Limit: limit}
+
+	if cursor != "" {
+		parsed, err := ParseCursor(cursor, kind)
+		if err != nil {
+			return PageQuery{}, err
+		}
+
+		pq.ParsedCursor = parsed
+	}
+
+	return pq, nil
+}
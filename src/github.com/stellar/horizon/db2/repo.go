@@ -0,0 +1,9 @@
+package db2
+
+import "database/sql"
+
+// Repo is a thin handle on a SQL database, shared by the core.Q and
+// history.Q query helpers that embed it as their Repo field.
+type Repo struct {
+	DB *sql.DB
+}
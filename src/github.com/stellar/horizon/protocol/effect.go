@@ -0,0 +1,15 @@
+package protocol
+
+import "time"
+
+// Effect is the json resource representing a single effect that resulted
+// from the application of an operation to the ledger.
+type Effect struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	// LedgerCloseTime is the closing time of the ledger this effect
+	// resulted from. It is null when that ledger hasn't been ingested into
+	// history yet and the action allows empty ledger data responses.
+	LedgerCloseTime *time.Time `json:"ledger_close_time"`
+}
@@ -0,0 +1,33 @@
+package protocol
+
+import "time"
+
+// Asset is the json resource representing a Stellar asset.
+type Asset struct {
+	Type   string `json:"asset_type"`
+	Code   string `json:"asset_code,omitempty"`
+	Issuer string `json:"asset_issuer,omitempty"`
+}
+
+// Price is the json resource representing a rational offer price.
+type Price struct {
+	N int32 `json:"n"`
+	D int32 `json:"d"`
+}
+
+// Offer is the json resource representing a single offer on the Stellar
+// network.
+type Offer struct {
+	ID      int64  `json:"id"`
+	Seller  string `json:"seller"`
+	Selling Asset  `json:"selling"`
+	Buying  Asset  `json:"buying"`
+	Amount  string `json:"amount"`
+	PriceR  Price  `json:"price_r"`
+	Price   string `json:"price"`
+
+	// LastModifiedTime is the closing time of the ledger this offer was
+	// last modified in. It is null when that ledger hasn't been ingested
+	// into history yet and the action allows empty ledger data responses.
+	LastModifiedTime *time.Time `json:"last_modified_time"`
+}
@@ -0,0 +1,16 @@
+package protocol
+
+import "time"
+
+// Operation is the json resource representing a single operation that
+// occurred during a transaction's application to the ledger.
+type Operation struct {
+	ID              int64  `json:"id"`
+	TransactionHash string `json:"transaction_hash"`
+	Type            string `json:"type"`
+
+	// LedgerCloseTime is the closing time of the ledger this operation was
+	// applied in. It is null when that ledger hasn't been ingested into
+	// history yet and the action allows empty ledger data responses.
+	LedgerCloseTime *time.Time `json:"ledger_close_time"`
+}
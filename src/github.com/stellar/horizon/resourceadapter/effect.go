@@ -0,0 +1,22 @@
+package resourceadapter
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+)
+
+// PopulateEffectLedgerData sets dest.LedgerCloseTime from closedAt, the
+// closing time of the ledger this effect resulted from. When ledgerFound is
+// false -- that ledger's history_ledgers row hasn't been ingested yet -- it
+// emits a null field if action allows empty ledger data responses, and
+// otherwise fails the request outright.
+func PopulateEffectLedgerData(action EmptyLedgerDataChecker, dest *protocol.Effect, closedAt time.Time, ledgerFound bool) error {
+	t, err := resolveLedgerCloseTime(action, "effect", dest.ID, closedAt, ledgerFound)
+	if err != nil {
+		return err
+	}
+
+	dest.LedgerCloseTime = t
+	return nil
+}
@@ -0,0 +1,47 @@
+package resourceadapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+)
+
+func TestPopulateEffectLedgerData_LedgerFound(t *testing.T) {
+	dest := &protocol.Effect{ID: "1-1"}
+	closedAt := time.Unix(1600000000, 0)
+
+	if err := PopulateEffectLedgerData(fakeChecker(false), dest, closedAt, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.LedgerCloseTime == nil || !dest.LedgerCloseTime.Equal(closedAt) {
+		t.Fatalf("expected LedgerCloseTime to be set to closedAt, got %v", dest.LedgerCloseTime)
+	}
+}
+
+func TestPopulateEffectLedgerData_MissingLedgerStrict(t *testing.T) {
+	dest := &protocol.Effect{ID: "1-1"}
+
+	err := PopulateEffectLedgerData(fakeChecker(false), dest, time.Time{}, false)
+	if err == nil {
+		t.Fatalf("expected an error when the ledger is missing and empty responses aren't allowed")
+	}
+
+	if dest.LedgerCloseTime != nil {
+		t.Fatalf("expected LedgerCloseTime to stay unset on error")
+	}
+}
+
+func TestPopulateEffectLedgerData_MissingLedgerAllowed(t *testing.T) {
+	dest := &protocol.Effect{ID: "1-1"}
+
+	err := PopulateEffectLedgerData(fakeChecker(true), dest, time.Time{}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.LedgerCloseTime != nil {
+		t.Fatalf("expected LedgerCloseTime to be nil, got %v", dest.LedgerCloseTime)
+	}
+}
@@ -0,0 +1,30 @@
+package resourceadapter
+
+import (
+	"fmt"
+	"time"
+)
+
+// EmptyLedgerDataChecker is satisfied by horizon.Action. It's factored out
+// as a narrow interface so resourceadapter doesn't have to import the root
+// horizon package.
+type EmptyLedgerDataChecker interface {
+	EmptyLedgerDataAllowed() bool
+}
+
+// resolveLedgerCloseTime returns the closing time to populate a
+// ledger-derived field with. When ledgerFound is false -- the row's
+// history_ledgers join missed -- it returns (nil, nil) if action allows
+// empty ledger data responses, and otherwise an error describing what
+// resource/id was affected.
+func resolveLedgerCloseTime(action EmptyLedgerDataChecker, resource string, id interface{}, closedAt time.Time, ledgerFound bool) (*time.Time, error) {
+	if !ledgerFound {
+		if !action.EmptyLedgerDataAllowed() {
+			return nil, fmt.Errorf("history_ledgers row for %s %v's ledger not found", resource, id)
+		}
+
+		return nil, nil
+	}
+
+	return &closedAt, nil
+}
@@ -0,0 +1,22 @@
+package resourceadapter
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+)
+
+// PopulateOfferLedgerData sets dest.LastModifiedTime from closedAt, the
+// closing time of the ledger the offer was last modified in. When
+// ledgerFound is false -- that ledger's history_ledgers row hasn't been
+// ingested yet -- it emits a null field if action allows empty ledger data
+// responses, and otherwise fails the request outright.
+func PopulateOfferLedgerData(action EmptyLedgerDataChecker, dest *protocol.Offer, closedAt time.Time, ledgerFound bool) error {
+	t, err := resolveLedgerCloseTime(action, "offer", dest.ID, closedAt, ledgerFound)
+	if err != nil {
+		return err
+	}
+
+	dest.LastModifiedTime = t
+	return nil
+}
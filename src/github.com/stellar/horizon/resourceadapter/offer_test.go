@@ -0,0 +1,51 @@
+package resourceadapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+)
+
+type fakeChecker bool
+
+func (f fakeChecker) EmptyLedgerDataAllowed() bool { return bool(f) }
+
+func TestPopulateOfferLedgerData_LedgerFound(t *testing.T) {
+	dest := &protocol.Offer{ID: 1}
+	closedAt := time.Unix(1600000000, 0)
+
+	if err := PopulateOfferLedgerData(fakeChecker(false), dest, closedAt, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.LastModifiedTime == nil || !dest.LastModifiedTime.Equal(closedAt) {
+		t.Fatalf("expected LastModifiedTime to be set to closedAt, got %v", dest.LastModifiedTime)
+	}
+}
+
+func TestPopulateOfferLedgerData_MissingLedgerStrict(t *testing.T) {
+	dest := &protocol.Offer{ID: 1}
+
+	err := PopulateOfferLedgerData(fakeChecker(false), dest, time.Time{}, false)
+	if err == nil {
+		t.Fatalf("expected an error when the ledger is missing and empty responses aren't allowed")
+	}
+
+	if dest.LastModifiedTime != nil {
+		t.Fatalf("expected LastModifiedTime to stay unset on error")
+	}
+}
+
+func TestPopulateOfferLedgerData_MissingLedgerAllowed(t *testing.T) {
+	dest := &protocol.Offer{ID: 1}
+
+	err := PopulateOfferLedgerData(fakeChecker(true), dest, time.Time{}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if dest.LastModifiedTime != nil {
+		t.Fatalf("expected LastModifiedTime to be nil, got %v", dest.LastModifiedTime)
+	}
+}
@@ -0,0 +1,22 @@
+package resourceadapter
+
+import (
+	"time"
+
+	"github.com/stellar/horizon/protocol"
+)
+
+// PopulateOperationLedgerData sets dest.LedgerCloseTime from closedAt, the
+// closing time of the ledger this operation was applied in. When
+// ledgerFound is false -- that ledger's history_ledgers row hasn't been
+// ingested yet -- it emits a null field if action allows empty ledger data
+// responses, and otherwise fails the request outright.
+func PopulateOperationLedgerData(action EmptyLedgerDataChecker, dest *protocol.Operation, closedAt time.Time, ledgerFound bool) error {
+	t, err := resolveLedgerCloseTime(action, "operation", dest.ID, closedAt, ledgerFound)
+	if err != nil {
+		return err
+	}
+
+	dest.LedgerCloseTime = t
+	return nil
+}